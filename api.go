@@ -0,0 +1,157 @@
+package telegram
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+)
+
+// apiURL is the base Telegram Bot API endpoint; overridable in tests.
+var apiURL = "https://api.telegram.org"
+
+// sendCommand posts params (a map or any JSON-marshalable struct) to
+// method and returns the raw response body for the caller to run through
+// extractOk and json.Unmarshal.
+func sendCommand(method, token string, params interface{}) ([]byte, error) {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	uri := fmt.Sprintf("%s/bot%s/%s", apiURL, token, method)
+	resp, err := http.Post(uri, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return io.ReadAll(resp.Body)
+}
+
+// sendFile posts params as multipart form fields alongside reader under
+// field, to method. It's used for uploads that need to stream file
+// contents rather than pass a file_id or URL as a plain string field.
+func sendFile(method, token, field string, reader io.Reader, params map[string]string) ([]byte, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	part, err := writer.CreateFormFile(field, field)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(part, reader); err != nil {
+		return nil, err
+	}
+
+	for key, value := range params {
+		if err := writer.WriteField(key, value); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	uri := fmt.Sprintf("%s/bot%s/%s", apiURL, token, method)
+	resp, err := http.Post(uri, writer.FormDataContentType(), body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return io.ReadAll(resp.Body)
+}
+
+// embedSendOptions folds options into params, under the same field names
+// every send/edit call uses.
+func embedSendOptions(params map[string]string, options *SendOptions) {
+	if options.ReplyTo.ID != 0 {
+		params["reply_to_message_id"] = fmt.Sprintf("%d", options.ReplyTo.ID)
+	}
+	if markup, err := json.Marshal(options.ReplyMarkup); err == nil && string(markup) != "{}" {
+		params["reply_markup"] = string(markup)
+	}
+	if options.DisableWebPagePreview {
+		params["disable_web_page_preview"] = "true"
+	}
+	if options.DisableNotification {
+		params["disable_notification"] = "true"
+	}
+	if options.ParseMode != ModeDefault {
+		params["parse_mode"] = string(options.ParseMode)
+	}
+}
+
+// getMe calls the getMe method, identifying the bot behind token.
+func getMe(token string) (User, error) {
+	responseJSON, err := sendCommand("getMe", token, map[string]string{})
+	if err != nil {
+		return User{}, err
+	}
+	if err := extractOk(responseJSON); err != nil {
+		return User{}, err
+	}
+
+	var resp struct {
+		Result User
+	}
+	if err := json.Unmarshal(responseJSON, &resp); err != nil {
+		return User{}, err
+	}
+
+	return resp.Result, nil
+}
+
+// getUpdates long-polls for new Updates starting at offset, waiting up to
+// timeoutSecs, capped at limit (zero means Telegram's default), restricted
+// to allowedUpdates (empty means Telegram's default set).
+func getUpdates(token string, offset, timeoutSecs int64, limit int, allowedUpdates []string) ([]Update, error) {
+	params := map[string]interface{}{
+		"offset":  offset,
+		"timeout": timeoutSecs,
+	}
+	if limit > 0 {
+		params["limit"] = limit
+	}
+	if len(allowedUpdates) > 0 {
+		params["allowed_updates"] = allowedUpdates
+	}
+
+	responseJSON, err := sendCommand("getUpdates", token, params)
+	if err != nil {
+		return nil, err
+	}
+	if err := extractOk(responseJSON); err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Result []Update
+	}
+	if err := json.Unmarshal(responseJSON, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Result, nil
+}
+
+// setWebhook registers endpoint as the bot's webhook URL.
+func setWebhook(token, endpoint string, maxConnections int, dropPendingUpdates bool) error {
+	params := map[string]interface{}{
+		"url":                  endpoint,
+		"drop_pending_updates": dropPendingUpdates,
+	}
+	if maxConnections > 0 {
+		params["max_connections"] = maxConnections
+	}
+
+	responseJSON, err := sendCommand("setWebhook", token, params)
+	if err != nil {
+		return err
+	}
+	return extractOk(responseJSON)
+}
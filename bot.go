@@ -2,10 +2,9 @@ package telegram
 
 import (
 	"encoding/json"
-	"fmt"
-	"log"
+	"errors"
+	"io"
 	"strconv"
-	"strings"
 	"sync"
 	"time"
 )
@@ -18,6 +17,17 @@ type Bot struct {
 	Queries   chan Query
 	Callbacks chan Callback
 	MultiWait time.Duration
+
+	// Poller supplies the Updates consumed by Start. Defaults to a
+	// LongPoller using MultiWait if left nil.
+	Poller Poller
+
+	// Limiter, when set, throttles outgoing calls made through Send to
+	// stay within Telegram's rate ceilings. Nil disables throttling.
+	Limiter *Limiter
+
+	handlers   map[string]HandlerFunc
+	middleware []MiddlewareFunc
 }
 
 // NewBot does try to build a TeleBot with token `token`, which
@@ -34,8 +44,55 @@ func NewBot(token string) (*Bot, error) {
 	}, nil
 }
 
+// Start pulls Updates from b.Poller (a LongPoller by default) and demuxes
+// them onto Messages, Queries and Callbacks until stop is closed.
+func (b *Bot) Start(stop chan struct{}) {
+	if b.Poller == nil {
+		b.Poller = LongPoller{MultiWait: b.MultiWait}
+	}
+
+	updates := make(chan Update)
+	go b.Poller.Poll(b, updates, stop)
+
+	for {
+		select {
+		case <-stop:
+			return
+		case update := <-updates:
+			b.dispatch(update)
+		}
+	}
+}
+
+// dispatch routes a single Update to the handler registered via Handle,
+// falling back to the legacy Messages/Queries/Callbacks channels when no
+// handler was registered for it.
+func (b *Bot) dispatch(update Update) {
+	if len(b.handlers) > 0 && b.route(update) != nil {
+		b.run(update)
+		return
+	}
+
+	if update.Payload != nil /* if message */ {
+		if b.Messages != nil {
+			b.Messages <- *update.Payload
+		}
+	} else if update.Query != nil /* if query */ {
+		if b.Queries != nil {
+			b.Queries <- *update.Query
+		}
+	} else if update.Callback != nil {
+		if b.Callbacks != nil {
+			b.Callbacks <- *update.Callback
+		}
+	}
+}
+
 // Listen periodically looks for updates and delivers new messages
 // to the subscription channel.
+//
+// Deprecated: assign Messages, Queries and Callbacks and call Start
+// instead.
 func (b *Bot) Listen(
 	messages chan Message,
 	queries chan Query,
@@ -45,95 +102,77 @@ func (b *Bot) Listen(
 	wg *sync.WaitGroup,
 ) {
 	defer wg.Done()
-	b.poll(messages, queries, callbacks, timeout, stop)
-}
-
-func (b *Bot) poll(
-	messages chan Message,
-	queries chan Query,
-	callbacks chan Callback,
-	timeout time.Duration,
-	stop chan bool,
-) {
-	var latestUpdate int64
 
-	for {
-		select {
-		case <-stop:
-			return
-		default:
-			updates, err := getUpdates(b.Token,
-				latestUpdate+1,
-				int64(timeout/time.Second),
-			)
-
-			if err != nil {
-				log.Println("failed to get updates:", err)
-				if strings.Index(err.Error(), "terminated by other long poll or webhook") > -1 {
-					log.Println("applying sleep-lock for failover instances")
-					time.Sleep(b.MultiWait)
-				}
-				continue
-			}
-
-			for _, update := range updates {
-				if update.Payload != nil /* if message */ {
-					if messages == nil {
-						continue
-					}
-
-					messages <- *update.Payload
-				} else if update.Query != nil /* if query */ {
-					if queries == nil {
-						continue
-					}
-
-					queries <- *update.Query
-				} else if update.Callback != nil {
-					if callbacks == nil {
-						continue
-					}
-
-					callbacks <- *update.Callback
-				}
-
-				latestUpdate = update.ID
-			}
-		}
+	b.Messages, b.Queries, b.Callbacks = messages, queries, callbacks
+	if b.Poller == nil {
+		b.Poller = LongPoller{Timeout: timeout, MultiWait: b.MultiWait}
 	}
-}
 
-// SendMessage sends a text message to recipient.
-func (b *Bot) SendMessage(recipient Recipient, message string, options *SendOptions) error {
-	params := map[string]string{
-		"chat_id": recipient.Destination(),
-		"text":    message,
-	}
+	innerStop := make(chan struct{})
+	go func() {
+		<-stop
+		close(innerStop)
+	}()
 
-	if options != nil {
-		embedSendOptions(params, options)
-	}
+	b.Start(innerStop)
+}
 
-	responseJSON, err := sendCommand("sendMessage", b.Token, params)
+// Send delivers what to recipient, applying any SendOptions.
+//
+// what must implement Sendable, which Text and every media type (Photo,
+// Video, Audio, Document, Sticker, Voice, VideoNote, Location, Venue,
+// Contact) already does. It's the single entrypoint behind SendMessage,
+// SendPhoto and friends, and the one to reach for when sending a new kind
+// of Sendable.
+//
+// Every Sendable.Send implementation issues its request through b.call or
+// b.callFile, so b.Limiter throttling already applies by the time Send
+// returns. A 429 is retried once for requests made through b.call; uploads
+// made through b.callFile are not retried.
+func (b *Bot) Send(recipient Recipient, what Sendable, options *SendOptions) (*Message, error) {
+	return what.Send(b, recipient, options)
+}
+
+// call posts params to method via sendCommand, waiting on b.Limiter for
+// recipient first. recipient may be nil for calls that aren't scoped to a
+// particular chat (e.g. getFile); the global bucket still applies. If
+// Telegram responds with ErrTooManyRequests, call sleeps RetryAfter and
+// retries once before handing the (possibly still failing) response back
+// to the caller, which should still run it through extractOk itself. This
+// depends on APIError.Is matching Telegram's actual, category-prefixed
+// description (e.g. "Too Many Requests: retry after 3"), not just the
+// bare sentinel one.
+func (b *Bot) call(recipient Recipient, method string, params interface{}) ([]byte, error) {
+	b.Limiter.Wait(recipient)
+
+	responseJSON, err := sendCommand(method, b.Token, params)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	var responseRecieved struct {
-		Ok          bool
-		Description string
+	var apiErr *APIError
+	if errors.As(extractOk(responseJSON), &apiErr) && errors.Is(apiErr, ErrTooManyRequests) {
+		time.Sleep(apiErr.RetryAfter())
+		return sendCommand(method, b.Token, params)
 	}
 
-	err = json.Unmarshal(responseJSON, &responseRecieved)
-	if err != nil {
-		return err
-	}
+	return responseJSON, nil
+}
 
-	if !responseRecieved.Ok {
-		return fmt.Errorf("telebot: %s", responseRecieved.Description)
-	}
+// callFile is call for uploads that stream from reader via sendFile.
+// Unlike call, a 429 isn't retried: reader has already been drained and
+// generally can't be replayed.
+func (b *Bot) callFile(recipient Recipient, method, field string, reader io.Reader, params map[string]string) ([]byte, error) {
+	b.Limiter.Wait(recipient)
+	return sendFile(method, b.Token, field, reader, params)
+}
 
-	return nil
+// SendMessage sends a text message to recipient.
+//
+// Deprecated: use Send(recipient, Text(message), options) instead.
+func (b *Bot) SendMessage(recipient Recipient, message string, options *SendOptions) error {
+	_, err := b.Send(recipient, Text(message), options)
+	return err
 }
 
 // SendPhoto sends a photo object to recipient.
@@ -142,52 +181,11 @@ func (b *Bot) SendMessage(recipient Recipient, message string, options *SendOpti
 // the Telegram servers, so sending the same photo object
 // again, won't issue a new upload, but would make a use
 // of existing file on Telegram servers.
+//
+// Deprecated: use Send(recipient, photo, options) instead.
 func (b *Bot) SendPhoto(recipient Recipient, photo *Photo, options *SendOptions) error {
-	params := map[string]string{
-		"chat_id": recipient.Destination(),
-		"caption": photo.Caption,
-	}
-
-	if options != nil {
-		embedSendOptions(params, options)
-	}
-
-	var responseJSON []byte
-	var err error
-
-	if photo.Exists() {
-		params["photo"] = photo.FileID
-		responseJSON, err = sendCommand("sendPhoto", b.Token, params)
-	} else {
-		responseJSON, err = sendFile("sendPhoto", b.Token, "photo",
-			photo.filename, params)
-	}
-
-	if err != nil {
-		return err
-	}
-
-	var responseRecieved struct {
-		Ok          bool
-		Result      Message
-		Description string
-	}
-
-	err = json.Unmarshal(responseJSON, &responseRecieved)
-	if err != nil {
-		return err
-	}
-
-	if !responseRecieved.Ok {
-		return fmt.Errorf("telebot: %s", responseRecieved.Description)
-	}
-
-	thumbnails := &responseRecieved.Result.Photo
-	filename := photo.filename
-	photo.File = (*thumbnails)[len(*thumbnails)-1].File
-	photo.filename = filename
-
-	return nil
+	_, err := b.Send(recipient, photo, options)
+	return err
 }
 
 // EditMessageText sends a edited text to recipient.
@@ -201,26 +199,12 @@ func (b *Bot) EditMessageText(recipient Recipient, message string, options *Send
 		embedSendOptions(params, options)
 	}
 
-	responseJSON, err := sendCommand("editMessageText", b.Token, params)
+	responseJSON, err := b.call(recipient, "editMessageText", params)
 	if err != nil {
 		return err
 	}
 
-	var responseRecieved struct {
-		Ok          bool
-		Description string
-	}
-
-	err = json.Unmarshal(responseJSON, &responseRecieved)
-	if err != nil {
-		return err
-	}
-
-	if !responseRecieved.Ok {
-		return fmt.Errorf("telebot: %s", responseRecieved.Description)
-	}
-
-	return nil
+	return extractOk(responseJSON)
 }
 
 // ForwardMessage forwards a message to recipient.
@@ -231,26 +215,12 @@ func (b *Bot) ForwardMessage(recipient Recipient, message Message) error {
 		"message_id":   strconv.Itoa(message.ID),
 	}
 
-	responseJSON, err := sendCommand("forwardMessage", b.Token, params)
+	responseJSON, err := b.call(recipient, "forwardMessage", params)
 	if err != nil {
 		return err
 	}
 
-	var responseRecieved struct {
-		Ok          bool
-		Description string
-	}
-
-	err = json.Unmarshal(responseJSON, &responseRecieved)
-	if err != nil {
-		return err
-	}
-
-	if !responseRecieved.Ok {
-		return fmt.Errorf("telebot: %s", responseRecieved.Description)
-	}
-
-	return nil
+	return extractOk(responseJSON)
 }
 
 // GetChat get up to date information about the chat.
@@ -263,27 +233,21 @@ func (b *Bot) GetChat(recipient Recipient) (Chat, error) {
 	params := map[string]string{
 		"chat_id": recipient.Destination(),
 	}
-	responseJSON, err := sendCommand("getChat", b.Token, params)
+	responseJSON, err := b.call(recipient, "getChat", params)
 	if err != nil {
 		return Chat{}, err
 	}
+	if err := extractOk(responseJSON); err != nil {
+		return Chat{}, err
+	}
 
 	var responseRecieved struct {
-		Ok          bool
-		Description string
-		Result      Chat
+		Result Chat
 	}
-
-	err = json.Unmarshal(responseJSON, &responseRecieved)
-	if err != nil {
+	if err := json.Unmarshal(responseJSON, &responseRecieved); err != nil {
 		return Chat{}, err
 	}
 
-	if !responseRecieved.Ok {
-		return Chat{}, fmt.Errorf("telebot: getChat failure %s",
-			responseRecieved.Description)
-	}
-
 	return responseRecieved.Result, nil
 }
 
@@ -294,26 +258,12 @@ func (b *Bot) SendChatAction(recipient Recipient, action ChatAction) error {
 		"action":  string(action),
 	}
 
-	responseJSON, err := sendCommand("sendChatAction", b.Token, params)
-	if err != nil {
-		return err
-	}
-
-	var responseRecieved struct {
-		Ok          bool
-		Description string
-	}
-
-	err = json.Unmarshal(responseJSON, &responseRecieved)
+	responseJSON, err := b.call(recipient, "sendChatAction", params)
 	if err != nil {
 		return err
 	}
 
-	if !responseRecieved.Ok {
-		return fmt.Errorf("telebot: %s", responseRecieved.Description)
-	}
-
-	return nil
+	return extractOk(responseJSON)
 }
 
 // AnswerCallbackQuery sends a response for a given callback query. A callback can
@@ -322,53 +272,28 @@ func (b *Bot) SendChatAction(recipient Recipient, action ChatAction) error {
 func (b *Bot) AnswerCallbackQuery(callback *Callback, response *CallbackResponse) error {
 	response.CallbackID = callback.ID
 
-	responseJSON, err := sendCommand("answerCallbackQuery", b.Token, response)
+	responseJSON, err := b.call(nil, "answerCallbackQuery", response)
 	if err != nil {
 		return err
 	}
 
-	var responseRecieved struct {
-		Ok          bool
-		Description string
-	}
-
-	err = json.Unmarshal(responseJSON, &responseRecieved)
-	if err != nil {
-		return err
-	}
-
-	if !responseRecieved.Ok {
-		return fmt.Errorf("telebot: %s", responseRecieved.Description)
-	}
-
-	return nil
+	return extractOk(responseJSON)
 }
 
 // DeleteMessage removes message by its id
+//
+// Deprecated: use Delete(editable) instead, which also works for messages
+// behind an inline Callback.
 func (b *Bot) DeleteMessage(recipient Recipient, messageId int) error {
 	params := map[string]interface{}{
 		"chat_id":    recipient.Destination(),
 		"message_id": messageId,
 	}
 
-	responseJSON, err := sendCommand("deleteMessage", b.Token, params)
+	responseJSON, err := b.call(recipient, "deleteMessage", params)
 	if err != nil {
 		return err
 	}
 
-	var responseRecieved struct {
-		Ok          bool
-		Description string
-	}
-
-	err = json.Unmarshal(responseJSON, &responseRecieved)
-	if err != nil {
-		return err
-	}
-
-	if !responseRecieved.Ok {
-		return fmt.Errorf("telebot: %s", responseRecieved.Description)
-	}
-
-	return nil
+	return extractOk(responseJSON)
 }
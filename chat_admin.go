@@ -0,0 +1,391 @@
+package telegram
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// ChatPermissions describes what regular (non-admin) members of a chat
+// are allowed to do, used by RestrictChatMember.
+type ChatPermissions struct {
+	CanSendMessages       bool `json:"can_send_messages,omitempty"`
+	CanSendMediaMessages  bool `json:"can_send_media_messages,omitempty"`
+	CanSendPolls          bool `json:"can_send_polls,omitempty"`
+	CanSendOtherMessages  bool `json:"can_send_other_messages,omitempty"`
+	CanAddWebPagePreviews bool `json:"can_add_web_page_previews,omitempty"`
+	CanChangeInfo         bool `json:"can_change_info,omitempty"`
+	CanInviteUsers        bool `json:"can_invite_users,omitempty"`
+	CanPinMessages        bool `json:"can_pin_messages,omitempty"`
+}
+
+// Rights describes the administrator privileges granted by
+// PromoteChatMember.
+type Rights struct {
+	CanChangeInfo      bool `json:"can_change_info,omitempty"`
+	CanPostMessages    bool `json:"can_post_messages,omitempty"`
+	CanEditMessages    bool `json:"can_edit_messages,omitempty"`
+	CanDeleteMessages  bool `json:"can_delete_messages,omitempty"`
+	CanInviteUsers     bool `json:"can_invite_users,omitempty"`
+	CanRestrictMembers bool `json:"can_restrict_members,omitempty"`
+	CanPinMessages     bool `json:"can_pin_messages,omitempty"`
+	CanPromoteMembers  bool `json:"can_promote_members,omitempty"`
+}
+
+// ChatMember represents one member of a chat, as returned by
+// GetChatMember and GetChatAdministrators.
+//
+// Rights and ChatPermissions aren't embedded here: both declare
+// CanChangeInfo, CanInviteUsers and CanPinMessages with identical json
+// tags, and encoding/json silently leaves ambiguously-tagged promoted
+// fields zero-valued on unmarshal. ChatMember instead lists every
+// admin-rights and member-permission field explicitly.
+type ChatMember struct {
+	User User `json:"user"`
+
+	// Status is one of "creator", "administrator", "member",
+	// "restricted", "left" or "kicked".
+	Status string `json:"status"`
+
+	// CustomTitle is the administrator's custom title, if set via
+	// SetChatAdministratorCustomTitle.
+	CustomTitle string `json:"custom_title"`
+
+	// Administrator rights, set when Status is "creator" or
+	// "administrator".
+	CanChangeInfo      bool `json:"can_change_info,omitempty"`
+	CanPostMessages    bool `json:"can_post_messages,omitempty"`
+	CanEditMessages    bool `json:"can_edit_messages,omitempty"`
+	CanDeleteMessages  bool `json:"can_delete_messages,omitempty"`
+	CanInviteUsers     bool `json:"can_invite_users,omitempty"`
+	CanRestrictMembers bool `json:"can_restrict_members,omitempty"`
+	CanPinMessages     bool `json:"can_pin_messages,omitempty"`
+	CanPromoteMembers  bool `json:"can_promote_members,omitempty"`
+
+	// Member permissions, set when Status is "restricted".
+	CanSendMessages       bool `json:"can_send_messages,omitempty"`
+	CanSendMediaMessages  bool `json:"can_send_media_messages,omitempty"`
+	CanSendPolls          bool `json:"can_send_polls,omitempty"`
+	CanSendOtherMessages  bool `json:"can_send_other_messages,omitempty"`
+	CanAddWebPagePreviews bool `json:"can_add_web_page_previews,omitempty"`
+
+	// Until is when a "restricted" or "kicked" status expires; zero
+	// means forever.
+	Until int64 `json:"until_date"`
+}
+
+// ChatInviteLink represents an invite link to a chat, as returned by
+// CreateChatInviteLink, EditChatInviteLink and RevokeChatInviteLink
+// (ExportChatInviteLink returns the bare link string instead).
+type ChatInviteLink struct {
+	InviteLink  string `json:"invite_link"`
+	Creator     User   `json:"creator"`
+	IsPrimary   bool   `json:"is_primary"`
+	IsRevoked   bool   `json:"is_revoked"`
+	Name        string `json:"name,omitempty"`
+	ExpireDate  int64  `json:"expire_date,omitempty"`
+	MemberLimit int    `json:"member_limit,omitempty"`
+
+	// CreatesJoinRequest requires users joining via this link to be
+	// approved through ApproveChatJoinRequest/DeclineChatJoinRequest.
+	CreatesJoinRequest bool `json:"creates_join_request,omitempty"`
+}
+
+// chatMemberCall posts params for a member-management method and discards
+// the response body beyond its ok/error envelope.
+func chatMemberCall(b *Bot, chat Recipient, method string, params map[string]interface{}) error {
+	responseJSON, err := b.call(chat, method, params)
+	if err != nil {
+		return err
+	}
+	return extractOk(responseJSON)
+}
+
+// BanChatMember bans userID from chat, optionally until untilUnix (zero
+// means forever).
+func (b *Bot) BanChatMember(chat Recipient, userID int, untilUnix int64) error {
+	return chatMemberCall(b, chat, "banChatMember", map[string]interface{}{
+		"chat_id":    chat.Destination(),
+		"user_id":    userID,
+		"until_date": untilUnix,
+	})
+}
+
+// UnbanChatMember lifts a ban on userID in chat.
+func (b *Bot) UnbanChatMember(chat Recipient, userID int) error {
+	return chatMemberCall(b, chat, "unbanChatMember", map[string]interface{}{
+		"chat_id": chat.Destination(),
+		"user_id": userID,
+	})
+}
+
+// RestrictChatMember applies perms to userID in chat, optionally until
+// untilUnix (zero means forever).
+func (b *Bot) RestrictChatMember(chat Recipient, userID int, perms ChatPermissions, untilUnix int64) error {
+	return chatMemberCall(b, chat, "restrictChatMember", map[string]interface{}{
+		"chat_id":     chat.Destination(),
+		"user_id":     userID,
+		"permissions": perms,
+		"until_date":  untilUnix,
+	})
+}
+
+// PromoteChatMember grants rights to userID in chat.
+func (b *Bot) PromoteChatMember(chat Recipient, userID int, rights Rights) error {
+	params := map[string]interface{}{
+		"chat_id":              chat.Destination(),
+		"user_id":              userID,
+		"can_change_info":      rights.CanChangeInfo,
+		"can_post_messages":    rights.CanPostMessages,
+		"can_edit_messages":    rights.CanEditMessages,
+		"can_delete_messages":  rights.CanDeleteMessages,
+		"can_invite_users":     rights.CanInviteUsers,
+		"can_restrict_members": rights.CanRestrictMembers,
+		"can_pin_messages":     rights.CanPinMessages,
+		"can_promote_members":  rights.CanPromoteMembers,
+	}
+	return chatMemberCall(b, chat, "promoteChatMember", params)
+}
+
+// SetChatAdministratorCustomTitle sets userID's custom admin title in
+// chat.
+func (b *Bot) SetChatAdministratorCustomTitle(chat Recipient, userID int, title string) error {
+	return chatMemberCall(b, chat, "setChatAdministratorCustomTitle", map[string]interface{}{
+		"chat_id":      chat.Destination(),
+		"user_id":      userID,
+		"custom_title": title,
+	})
+}
+
+// GetChatAdministrators lists the administrators (and creator) of chat.
+func (b *Bot) GetChatAdministrators(chat Recipient) ([]ChatMember, error) {
+	params := map[string]string{"chat_id": chat.Destination()}
+
+	responseJSON, err := b.call(chat, "getChatAdministrators", params)
+	if err != nil {
+		return nil, err
+	}
+	if err := extractOk(responseJSON); err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Result []ChatMember
+	}
+	if err := json.Unmarshal(responseJSON, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Result, nil
+}
+
+// GetChatMember fetches userID's membership info in chat.
+func (b *Bot) GetChatMember(chat Recipient, userID int) (ChatMember, error) {
+	params := map[string]string{
+		"chat_id": chat.Destination(),
+		"user_id": strconv.Itoa(userID),
+	}
+
+	responseJSON, err := b.call(chat, "getChatMember", params)
+	if err != nil {
+		return ChatMember{}, err
+	}
+	if err := extractOk(responseJSON); err != nil {
+		return ChatMember{}, err
+	}
+
+	var resp struct {
+		Result ChatMember
+	}
+	if err := json.Unmarshal(responseJSON, &resp); err != nil {
+		return ChatMember{}, err
+	}
+
+	return resp.Result, nil
+}
+
+// GetChatMemberCount returns the number of members in chat.
+func (b *Bot) GetChatMemberCount(chat Recipient) (int, error) {
+	params := map[string]string{"chat_id": chat.Destination()}
+
+	responseJSON, err := b.call(chat, "getChatMemberCount", params)
+	if err != nil {
+		return 0, err
+	}
+	if err := extractOk(responseJSON); err != nil {
+		return 0, err
+	}
+
+	var resp struct {
+		Result int
+	}
+	if err := json.Unmarshal(responseJSON, &resp); err != nil {
+		return 0, err
+	}
+
+	return resp.Result, nil
+}
+
+// SetChatTitle renames chat.
+func (b *Bot) SetChatTitle(chat Recipient, title string) error {
+	return chatMemberCall(b, chat, "setChatTitle", map[string]interface{}{
+		"chat_id": chat.Destination(),
+		"title":   title,
+	})
+}
+
+// SetChatDescription sets chat's description.
+func (b *Bot) SetChatDescription(chat Recipient, description string) error {
+	return chatMemberCall(b, chat, "setChatDescription", map[string]interface{}{
+		"chat_id":     chat.Destination(),
+		"description": description,
+	})
+}
+
+// SetChatPhoto uploads photo as chat's new photo.
+func (b *Bot) SetChatPhoto(chat Recipient, photo *Photo) error {
+	params := map[string]string{"chat_id": chat.Destination()}
+
+	responseJSON, err := sendMedia(b, chat, "setChatPhoto", "photo", &photo.File, params)
+	if err != nil {
+		return err
+	}
+	return extractOk(responseJSON)
+}
+
+// DeleteChatPhoto removes chat's current photo.
+func (b *Bot) DeleteChatPhoto(chat Recipient) error {
+	return chatMemberCall(b, chat, "deleteChatPhoto", map[string]interface{}{
+		"chat_id": chat.Destination(),
+	})
+}
+
+// PinChatMessage pins editable in its chat.
+func (b *Bot) PinChatMessage(editable Editable, silent bool) error {
+	params := map[string]string{"disable_notification": strconv.FormatBool(silent)}
+	editParams(editable, params)
+
+	responseJSON, err := b.call(chatOf(editable), "pinChatMessage", params)
+	if err != nil {
+		return err
+	}
+	return extractOk(responseJSON)
+}
+
+// UnpinChatMessage unpins editable, which must not be nil; there's no way
+// to unpin every message in a chat without knowing which chat, so callers
+// wanting that should pass the chat's own pinned message instead.
+func (b *Bot) UnpinChatMessage(editable Editable) error {
+	if editable == nil {
+		return fmt.Errorf("telebot: UnpinChatMessage requires a non-nil Editable")
+	}
+
+	params := map[string]string{}
+	editParams(editable, params)
+
+	responseJSON, err := b.call(chatOf(editable), "unpinChatMessage", params)
+	if err != nil {
+		return err
+	}
+	return extractOk(responseJSON)
+}
+
+// LeaveChat makes the bot leave chat.
+func (b *Bot) LeaveChat(chat Recipient) error {
+	return chatMemberCall(b, chat, "leaveChat", map[string]interface{}{
+		"chat_id": chat.Destination(),
+	})
+}
+
+// ExportChatInviteLink generates a new primary invite link for chat,
+// revoking any previous one.
+func (b *Bot) ExportChatInviteLink(chat Recipient) (string, error) {
+	params := map[string]string{"chat_id": chat.Destination()}
+
+	responseJSON, err := b.call(chat, "exportChatInviteLink", params)
+	if err != nil {
+		return "", err
+	}
+	if err := extractOk(responseJSON); err != nil {
+		return "", err
+	}
+
+	var resp struct {
+		Result string
+	}
+	if err := json.Unmarshal(responseJSON, &resp); err != nil {
+		return "", err
+	}
+
+	return resp.Result, nil
+}
+
+// CreateChatInviteLink creates an additional invite link for chat. expireUnix
+// and memberLimit of zero leave that restriction unset; joinRequest requires
+// manual approval of anyone joining via the link.
+func (b *Bot) CreateChatInviteLink(chat Recipient, name string, expireUnix int64, memberLimit int, joinRequest bool) (ChatInviteLink, error) {
+	return inviteLinkCall(b, chat, "createChatInviteLink", map[string]interface{}{
+		"chat_id":              chat.Destination(),
+		"name":                 name,
+		"expire_date":          expireUnix,
+		"member_limit":         memberLimit,
+		"creates_join_request": joinRequest,
+	})
+}
+
+// EditChatInviteLink updates a previously created invite link.
+func (b *Bot) EditChatInviteLink(chat Recipient, inviteLink, name string, expireUnix int64, memberLimit int, joinRequest bool) (ChatInviteLink, error) {
+	return inviteLinkCall(b, chat, "editChatInviteLink", map[string]interface{}{
+		"chat_id":              chat.Destination(),
+		"invite_link":          inviteLink,
+		"name":                 name,
+		"expire_date":          expireUnix,
+		"member_limit":         memberLimit,
+		"creates_join_request": joinRequest,
+	})
+}
+
+// RevokeChatInviteLink revokes inviteLink, making it no longer usable.
+func (b *Bot) RevokeChatInviteLink(chat Recipient, inviteLink string) (ChatInviteLink, error) {
+	return inviteLinkCall(b, chat, "revokeChatInviteLink", map[string]interface{}{
+		"chat_id":     chat.Destination(),
+		"invite_link": inviteLink,
+	})
+}
+
+// inviteLinkCall posts params for an invite-link method and decodes the
+// resulting ChatInviteLink.
+func inviteLinkCall(b *Bot, chat Recipient, method string, params map[string]interface{}) (ChatInviteLink, error) {
+	responseJSON, err := b.call(chat, method, params)
+	if err != nil {
+		return ChatInviteLink{}, err
+	}
+	if err := extractOk(responseJSON); err != nil {
+		return ChatInviteLink{}, err
+	}
+
+	var resp struct {
+		Result ChatInviteLink
+	}
+	if err := json.Unmarshal(responseJSON, &resp); err != nil {
+		return ChatInviteLink{}, err
+	}
+
+	return resp.Result, nil
+}
+
+// ApproveChatJoinRequest approves userID's pending request to join chat.
+func (b *Bot) ApproveChatJoinRequest(chat Recipient, userID int) error {
+	return chatMemberCall(b, chat, "approveChatJoinRequest", map[string]interface{}{
+		"chat_id": chat.Destination(),
+		"user_id": userID,
+	})
+}
+
+// DeclineChatJoinRequest declines userID's pending request to join chat.
+func (b *Bot) DeclineChatJoinRequest(chat Recipient, userID int) error {
+	return chatMemberCall(b, chat, "declineChatJoinRequest", map[string]interface{}{
+		"chat_id": chat.Destination(),
+		"user_id": userID,
+	})
+}
@@ -0,0 +1,188 @@
+package telegram
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Editable is anything Telegram can edit or delete in place: a Message
+// (by chat_id + message_id) or a Callback carrying an inline message (by
+// inline_message_id).
+type Editable interface {
+	// MessageSig returns the identifiers Telegram needs to act on this
+	// message: either (messageID, chatID) for a chat message, or
+	// (inlineMessageID, 0) for an inline result.
+	MessageSig() (messageID string, chatID int64)
+}
+
+// MessageSig implements Editable.
+func (m *Message) MessageSig() (string, int64) {
+	return strconv.Itoa(m.ID), m.Chat.ID
+}
+
+// MessageSig implements Editable.
+func (c *Callback) MessageSig() (string, int64) {
+	if c.Message != nil {
+		return c.Message.MessageSig()
+	}
+	return c.MessageID, 0
+}
+
+// chatOf returns the chat editable lives in, for Limiter purposes, or nil
+// for an inline result (MessageSig's chatID is 0), which isn't scoped to
+// any chat the Limiter tracks.
+func chatOf(editable Editable) Recipient {
+	_, chatID := editable.MessageSig()
+	if chatID == 0 {
+		return nil
+	}
+	return &Chat{ID: chatID}
+}
+
+// editParams fills params with either chat_id+message_id or
+// inline_message_id, depending on what editable provides.
+func editParams(editable Editable, params map[string]string) {
+	messageID, chatID := editable.MessageSig()
+	if chatID != 0 {
+		params["chat_id"] = strconv.FormatInt(chatID, 10)
+		params["message_id"] = messageID
+	} else {
+		params["inline_message_id"] = messageID
+	}
+}
+
+// Edit edits editable (a previously sent Message, or an inline result
+// referenced by a Callback) to read as what. A Text replaces the message
+// text; a *Photo replaces only the caption, keeping the photo itself. Any
+// other Sendable returns an error.
+func (b *Bot) Edit(editable Editable, what Sendable, options *SendOptions) (*Message, error) {
+	var (
+		method string
+		params = map[string]string{}
+	)
+
+	switch v := what.(type) {
+	case Text:
+		method = "editMessageText"
+		params["text"] = string(v)
+	case *Photo:
+		method = "editMessageCaption"
+		params["caption"] = v.Caption
+	default:
+		return nil, fmt.Errorf("telebot: Edit doesn't support %T yet", what)
+	}
+
+	editParams(editable, params)
+	if options != nil {
+		embedSendOptions(params, options)
+	}
+
+	responseJSON, err := b.call(chatOf(editable), method, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeMessage(responseJSON)
+}
+
+// EditCaption edits the caption of editable to newCaption.
+func (b *Bot) EditCaption(editable Editable, newCaption string, options *SendOptions) (*Message, error) {
+	params := map[string]string{"caption": newCaption}
+	editParams(editable, params)
+	if options != nil {
+		embedSendOptions(params, options)
+	}
+
+	responseJSON, err := b.call(chatOf(editable), "editMessageCaption", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeMessage(responseJSON)
+}
+
+// EditReplyMarkup replaces editable's inline keyboard with markup.
+func (b *Bot) EditReplyMarkup(editable Editable, markup *ReplyMarkup) (*Message, error) {
+	params := map[string]interface{}{}
+	editParamsAny(editable, params)
+	if markup != nil {
+		params["reply_markup"] = markup
+	}
+
+	responseJSON, err := b.call(chatOf(editable), "editMessageReplyMarkup", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeMessage(responseJSON)
+}
+
+// EditMedia is Edit under another name: for a *Photo it replaces only the
+// caption, and the media itself is not replaced. Video, Audio, Document and
+// the other media types return an error.
+func (b *Bot) EditMedia(editable Editable, media Sendable, options *SendOptions) (*Message, error) {
+	return b.Edit(editable, media, options)
+}
+
+// EditLiveLocation updates the live location previously sent as editable.
+func (b *Bot) EditLiveLocation(editable Editable, location *Location, options *SendOptions) (*Message, error) {
+	params := map[string]string{
+		"latitude":  strconv.FormatFloat(float64(location.Latitude), 'f', -1, 32),
+		"longitude": strconv.FormatFloat(float64(location.Longitude), 'f', -1, 32),
+	}
+	editParams(editable, params)
+	if options != nil {
+		embedSendOptions(params, options)
+	}
+
+	responseJSON, err := b.call(chatOf(editable), "editMessageLiveLocation", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeMessage(responseJSON)
+}
+
+// StopLiveLocation freezes the live location previously sent as editable
+// at its last known position.
+func (b *Bot) StopLiveLocation(editable Editable, options *SendOptions) (*Message, error) {
+	params := map[string]string{}
+	editParams(editable, params)
+	if options != nil {
+		embedSendOptions(params, options)
+	}
+
+	responseJSON, err := b.call(chatOf(editable), "stopMessageLiveLocation", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeMessage(responseJSON)
+}
+
+// Delete removes editable. Only messages sent by the bot itself, or any
+// message in a group where the bot is admin, can be deleted this way.
+func (b *Bot) Delete(editable Editable) error {
+	params := map[string]string{}
+	editParams(editable, params)
+
+	responseJSON, err := b.call(chatOf(editable), "deleteMessage", params)
+	if err != nil {
+		return err
+	}
+
+	return extractOk(responseJSON)
+}
+
+// editParamsAny is editParams for callers building a map[string]interface{}
+// instead of map[string]string, e.g. because they also need to embed a
+// non-string reply_markup.
+func editParamsAny(editable Editable, params map[string]interface{}) {
+	messageID, chatID := editable.MessageSig()
+	if chatID != 0 {
+		params["chat_id"] = strconv.FormatInt(chatID, 10)
+		params["message_id"] = messageID
+	} else {
+		params["inline_message_id"] = messageID
+	}
+}
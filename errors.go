@@ -0,0 +1,128 @@
+package telegram
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ResponseParameters carries extra information Telegram attaches to some
+// failed responses, such as where a chat migrated to or how long to back
+// off before retrying.
+type ResponseParameters struct {
+	MigrateToChatID int64 `json:"migrate_to_chat_id"`
+	RetryAfter      int   `json:"retry_after"`
+}
+
+// APIError is returned whenever Telegram responds with "ok": false. Use
+// errors.Is against the Err* sentinels below to test for a specific
+// failure.
+type APIError struct {
+	Code        int
+	Description string
+	Parameters  *ResponseParameters
+}
+
+// Error implements error.
+func (e *APIError) Error() string {
+	return fmt.Sprintf("telebot: %s (%d)", e.Description, e.Code)
+}
+
+// Is reports whether target is an *APIError with the same Code whose
+// Description is contained in e's. Several sentinels intentionally share
+// a Code because Telegram doesn't give them distinct numeric ones (e.g.
+// ErrChatNotFound, ErrMessageNotModified and ErrGroupMigrated are all
+// 400), so Code alone isn't enough to tell them apart. Description isn't
+// compared for full equality either: Telegram prefixes the real message
+// with its category, e.g. "Bad Request: chat not found" or "Too Many
+// Requests: retry after 3", so a substring match is what actually lines
+// up with the bare sentinel Descriptions below.
+func (e *APIError) Is(target error) bool {
+	t, ok := target.(*APIError)
+	if !ok {
+		return false
+	}
+	return e.Code == t.Code && strings.Contains(e.Description, t.Description)
+}
+
+// RetryAfter returns how long to wait before retrying a request that
+// failed with ErrTooManyRequests, or zero if Telegram didn't say.
+func (e *APIError) RetryAfter() time.Duration {
+	if e.Parameters == nil {
+		return 0
+	}
+	return time.Duration(e.Parameters.RetryAfter) * time.Second
+}
+
+// MigrateToChatID returns the chat's new ID after an ErrGroupMigrated
+// failure, or zero if this isn't that error.
+func (e *APIError) MigrateToChatID() int64 {
+	if e.Parameters == nil {
+		return 0
+	}
+	return e.Parameters.MigrateToChatID
+}
+
+// Sentinel API errors, matched against by errors.Is(err, ErrXxx). Both
+// Code and Description are compared, since several of these intentionally
+// share a Code and Description is the only thing telling them apart.
+var (
+	ErrUnauthorized            = &APIError{Code: 401, Description: "Unauthorized"}
+	ErrForbidden               = &APIError{Code: 403, Description: "Forbidden"}
+	ErrNotFound                = &APIError{Code: 404, Description: "Not Found"}
+	ErrConflictLongPoll        = &APIError{Code: 409, Description: "terminated by other long poll or webhook"}
+	ErrTooManyRequests         = &APIError{Code: 429, Description: "Too Many Requests"}
+	ErrChatNotFound            = &APIError{Code: 400, Description: "chat not found"}
+	ErrBlockedByUser           = &APIError{Code: 403, Description: "bot was blocked by the user"}
+	ErrMessageNotModified      = &APIError{Code: 400, Description: "message is not modified"}
+	ErrMessageToDeleteNotFound = &APIError{Code: 400, Description: "message to delete not found"}
+	ErrGroupMigrated           = &APIError{Code: 400, Description: "group chat was upgraded to a supergroup chat"}
+)
+
+// errorsByDescription lists the sentinels Telegram doesn't assign a
+// distinct error_code to, so extractOk can still return a typed value by
+// matching their Description as a substring of the real, category-prefixed
+// one Telegram sends (e.g. "Bad Request: chat not found").
+var errorsByDescription = []*APIError{
+	ErrConflictLongPoll,
+	ErrChatNotFound,
+	ErrBlockedByUser,
+	ErrMessageNotModified,
+	ErrMessageToDeleteNotFound,
+	ErrGroupMigrated,
+}
+
+// extractOk decodes the common {ok, error_code, description, parameters}
+// envelope every Bot API response wraps its payload in, returning a typed
+// *APIError when ok is false and nil otherwise. Every response-decoding
+// call site should run its raw JSON through this before touching Result.
+func extractOk(responseJSON []byte) error {
+	var response struct {
+		Ok          bool                `json:"ok"`
+		ErrorCode   int                 `json:"error_code"`
+		Description string              `json:"description"`
+		Parameters  *ResponseParameters `json:"parameters"`
+	}
+
+	if err := json.Unmarshal(responseJSON, &response); err != nil {
+		return err
+	}
+	if response.Ok {
+		return nil
+	}
+
+	apiErr := &APIError{
+		Code:        response.ErrorCode,
+		Description: response.Description,
+		Parameters:  response.Parameters,
+	}
+	for _, known := range errorsByDescription {
+		if strings.Contains(response.Description, known.Description) {
+			apiErr.Code = known.Code
+			break
+		}
+	}
+
+	return apiErr
+}
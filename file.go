@@ -0,0 +1,100 @@
+package telegram
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+)
+
+// FileByID fetches f's FilePath (and refreshes its other fields) by
+// calling getFile. The returned File can then be passed to Download or
+// File(f).
+func (b *Bot) FileByID(fileID string) (File, error) {
+	params := map[string]string{"file_id": fileID}
+
+	responseJSON, err := b.call(nil, "getFile", params)
+	if err != nil {
+		return File{}, err
+	}
+	if err := extractOk(responseJSON); err != nil {
+		return File{}, err
+	}
+
+	var resp struct {
+		Result File
+	}
+	if err := json.Unmarshal(responseJSON, &resp); err != nil {
+		return File{}, err
+	}
+
+	return resp.Result, nil
+}
+
+// File opens the Telegram-hosted file behind f for reading. If f.FilePath
+// is empty, it is first fetched via FileByID(f.FileID) and stored in f.
+func (b *Bot) File(f *File) (io.ReadCloser, error) {
+	if f.FilePath == "" {
+		fetched, err := b.FileByID(f.FileID)
+		if err != nil {
+			return nil, err
+		}
+		f.FilePath = fetched.FilePath
+	}
+
+	url := fmt.Sprintf("https://api.telegram.org/file/bot%s/%s", b.Token, f.FilePath)
+
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("telebot: failed to download file, status %s", resp.Status)
+	}
+
+	return resp.Body, nil
+}
+
+// Download saves the Telegram-hosted file behind f to localPath.
+func (b *Bot) Download(f *File, localPath string) error {
+	reader, err := b.File(f)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	out, err := os.Create(localPath)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, reader)
+	return err
+}
+
+// FromDisk builds a File referencing the local file at path, to be
+// uploaded on first send.
+func FromDisk(path string) File {
+	return File{filename: path}
+}
+
+// FromURL builds a File Telegram will download itself from url, without
+// the bot uploading anything.
+func FromURL(url string) File {
+	return File{FileID: url}
+}
+
+// FromReader builds a File that will be streamed from r on first send,
+// without being written to disk first.
+func FromReader(r io.Reader) File {
+	return File{fileReader: r}
+}
+
+// FromFileID builds a File referencing a file already known to Telegram
+// by fileID, reusing it without a new upload.
+func FromFileID(fileID string) File {
+	return File{FileID: fileID}
+}
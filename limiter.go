@@ -0,0 +1,150 @@
+package telegram
+
+import (
+	"sync"
+	"time"
+)
+
+// LimiterOptions configures NewLimiter. Zero values for Global, PerChat and
+// PerGroup disable that particular ceiling.
+type LimiterOptions struct {
+	// Global caps messages/sec across all chats, e.g. Telegram's 30.
+	Global float64
+
+	// PerChat caps messages/sec to any single private chat, e.g.
+	// Telegram's 1.
+	PerChat float64
+
+	// PerGroup caps messages/min to any single group or supergroup, e.g.
+	// Telegram's 20.
+	PerGroup float64
+
+	// Burst is the size of every bucket, i.e. how many requests may fire
+	// back-to-back before the rate ceiling kicks in.
+	Burst int
+}
+
+// Limiter throttles outgoing Bot API calls to stay within Telegram's
+// documented ceilings: a global bucket in front of a per-chat bucket keyed
+// by chat_id. Assign one to Bot.Limiter, or leave it nil to disable
+// throttling entirely.
+type Limiter struct {
+	opts LimiterOptions
+
+	global *tokenBucket
+
+	mu     sync.Mutex
+	chats  map[string]*tokenBucket
+	groups map[string]*tokenBucket
+}
+
+// NewLimiter builds a Limiter from opts.
+func NewLimiter(opts LimiterOptions) *Limiter {
+	l := &Limiter{opts: opts, chats: make(map[string]*tokenBucket), groups: make(map[string]*tokenBucket)}
+	if opts.Global > 0 {
+		l.global = newTokenBucket(opts.Global, opts.Burst)
+	}
+	return l
+}
+
+// Wait blocks until a call to recipient is allowed to proceed, consuming
+// one token from the global bucket (if any) and the bucket matching
+// recipient, picking the PerGroup ceiling over PerChat when recipient is a
+// Chat (or *Chat) whose IsGroupChat reports true; that includes a Chat with
+// no Type set, such as the one chatOf builds. recipient may be nil for calls that aren't scoped to
+// any one chat (e.g. getFile), in which case only the global bucket
+// applies.
+func (l *Limiter) Wait(recipient Recipient) {
+	if l == nil {
+		return
+	}
+
+	if l.global != nil {
+		l.global.take()
+	}
+
+	if recipient == nil {
+		return
+	}
+
+	isGroup := false
+	switch chat := recipient.(type) {
+	case Chat:
+		isGroup = chat.IsGroupChat()
+	case *Chat:
+		if chat != nil {
+			isGroup = chat.IsGroupChat()
+		}
+	}
+
+	bucket := l.bucketFor(recipient.Destination(), isGroup)
+	if bucket != nil {
+		bucket.take()
+	}
+}
+
+func (l *Limiter) bucketFor(chatID string, isGroup bool) *tokenBucket {
+	rate := l.opts.PerChat
+	buckets := l.chats
+	if isGroup {
+		rate = l.opts.PerGroup / 60
+		buckets = l.groups
+	}
+	if rate <= 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	bucket, ok := buckets[chatID]
+	if !ok {
+		bucket = newTokenBucket(rate, l.opts.Burst)
+		buckets[chatID] = bucket
+	}
+	return bucket
+}
+
+// tokenBucket is a simple token-bucket rate limiter: ratePerSec tokens are
+// added per second, up to burst, and take blocks until one is available.
+type tokenBucket struct {
+	mu         sync.Mutex
+	ratePerSec float64
+	burst      float64
+	tokens     float64
+	updated    time.Time
+}
+
+func newTokenBucket(ratePerSec float64, burst int) *tokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{
+		ratePerSec: ratePerSec,
+		burst:      float64(burst),
+		tokens:     float64(burst),
+		updated:    time.Now(),
+	}
+}
+
+func (t *tokenBucket) take() {
+	for {
+		t.mu.Lock()
+		now := time.Now()
+		t.tokens += now.Sub(t.updated).Seconds() * t.ratePerSec
+		if t.tokens > t.burst {
+			t.tokens = t.burst
+		}
+		t.updated = now
+
+		if t.tokens >= 1 {
+			t.tokens--
+			t.mu.Unlock()
+			return
+		}
+
+		wait := time.Duration((1 - t.tokens) / t.ratePerSec * float64(time.Second))
+		t.mu.Unlock()
+		time.Sleep(wait)
+	}
+}
@@ -0,0 +1,78 @@
+package telegram
+
+// Message object represents a message.
+type Message struct {
+	ID   int   `json:"message_id"`
+	From User  `json:"from"`
+	Chat Chat  `json:"chat"`
+	Date int64 `json:"date"`
+
+	Text string `json:"text,omitempty"`
+
+	Photo     []Thumbnail `json:"photo,omitempty"`
+	Video     Video       `json:"video,omitempty"`
+	Audio     Audio       `json:"audio,omitempty"`
+	Document  Document    `json:"document,omitempty"`
+	Sticker   Sticker     `json:"sticker,omitempty"`
+	Voice     Voice       `json:"voice,omitempty"`
+	VideoNote VideoNote   `json:"video_note,omitempty"`
+	Location  *Location   `json:"location,omitempty"`
+	Venue     *Venue      `json:"venue,omitempty"`
+	Contact   *Contact    `json:"contact,omitempty"`
+
+	Caption string `json:"caption,omitempty"`
+}
+
+// Origin returns the user who sent the message.
+func (m *Message) Origin() User {
+	return m.From
+}
+
+// Query object represents an incoming inline query.
+type Query struct {
+	ID     string `json:"id"`
+	Sender User   `json:"from"`
+	Text   string `json:"query"`
+	Offset string `json:"offset"`
+}
+
+// Callback object represents an incoming callback query.
+type Callback struct {
+	ID string `json:"id"`
+
+	Sender User `json:"from"`
+
+	// Message is the message the inline keyboard was attached to, if it
+	// was a chat message rather than an inline result.
+	Message *Message `json:"message"`
+
+	// MessageID identifies an inline result's message, when Message is
+	// nil.
+	MessageID string `json:"inline_message_id"`
+
+	// Data is the callback_data of the button that was tapped.
+	Data string `json:"data"`
+}
+
+// CallbackResponse builds a response to an incoming Callback, passed to
+// Bot.AnswerCallbackQuery.
+type CallbackResponse struct {
+	// CallbackID is filled in by AnswerCallbackQuery; callers don't set
+	// it themselves.
+	CallbackID string `json:"callback_query_id"`
+
+	// Text is shown to the user, as a notification at the top of the
+	// chat screen or as an alert.
+	Text string `json:"text,omitempty"`
+
+	// ShowAlert, if true, shows Text as an alert instead of a
+	// notification.
+	ShowAlert bool `json:"show_alert,omitempty"`
+
+	// URL opens a deep link instead of showing Text.
+	URL string `json:"url,omitempty"`
+
+	// CacheTime is how long, in seconds, the result may be cached
+	// client-side.
+	CacheTime int `json:"cache_time,omitempty"`
+}
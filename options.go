@@ -71,9 +71,6 @@ type SendOptions struct {
 
 	// ParseMode controls how client apps render your message.
 	ParseMode ParseMode
-
-	// MessageId id of message
-	MessageId int
 }
 
 // ReplyMarkup specifies convenient options for bot-user communications.
@@ -0,0 +1,182 @@
+package telegram
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+)
+
+// Poller is a provider of Updates. It's used to decouple Bot.Start from the
+// transport that actually retrieves updates, so long polling and webhooks
+// can be swapped without touching handler code.
+type Poller interface {
+	// Poll starts delivering Updates to updates until something is sent to
+	// stop. Implementations must return promptly once stop is closed.
+	Poll(b *Bot, updates chan Update, stop chan struct{})
+}
+
+// LongPoller builds on getUpdates, repeatedly asking Telegram for new
+// Updates with a long-polling Timeout.
+type LongPoller struct {
+	// Timeout is passed to getUpdates as the long-poll duration.
+	Timeout time.Duration
+
+	// Limit caps the number of updates fetched per request, 1-100.
+	// Zero means Telegram's default.
+	Limit int
+
+	// AllowedUpdates restricts which update kinds are delivered, e.g.
+	// []string{"message", "callback_query"}. Empty means all kinds
+	// except chat_member, which Telegram requires you to opt into.
+	AllowedUpdates []string
+
+	// MultiWait is how long to sleep after a "terminated by other long
+	// poll or webhook" conflict, so that failover instances don't hammer
+	// the API while another instance is still holding the poll.
+	MultiWait time.Duration
+}
+
+// Poll implements Poller.
+func (p LongPoller) Poll(b *Bot, updates chan Update, stop chan struct{}) {
+	var latestUpdate int64
+
+	for {
+		select {
+		case <-stop:
+			return
+		default:
+			result, err := getUpdates(b.Token,
+				latestUpdate+1,
+				int64(p.Timeout/time.Second),
+				p.Limit,
+				p.AllowedUpdates,
+			)
+
+			if err != nil {
+				log.Println("failed to get updates:", err)
+				// errors.Is matches by substring against Telegram's real,
+				// category-prefixed description (see APIError.Is), so this
+				// still fires for the actual "Conflict: terminated by
+				// other long poll or webhook" Telegram sends.
+				if errors.Is(err, ErrConflictLongPoll) {
+					log.Println("applying sleep-lock for failover instances")
+					time.Sleep(p.MultiWait)
+				}
+				continue
+			}
+
+			for _, update := range result {
+				updates <- update
+				latestUpdate = update.ID
+			}
+		}
+	}
+}
+
+// WebhookPoller delivers Updates pushed by Telegram to a registered
+// webhook, instead of long-polling for them.
+type WebhookPoller struct {
+	// Listen is the local address to listen on, e.g. ":8443".
+	Listen string
+
+	// Endpoint is the public HTTPS URL Telegram should POST updates to,
+	// passed verbatim to setWebhook.
+	Endpoint string
+
+	// TLS, when set, is used to serve HTTPS directly instead of expecting
+	// a reverse proxy to terminate TLS in front of Listen.
+	TLS *WebhookTLS
+
+	// MaxConnections caps the number of simultaneous HTTPS connections
+	// Telegram will open to deliver updates, 1-100. Zero means Telegram's
+	// default of 40.
+	MaxConnections int
+
+	// DropPendingUpdates discards any updates queued before the webhook
+	// is (re)registered.
+	DropPendingUpdates bool
+}
+
+// WebhookTLS holds the certificate pair WebhookPoller serves directly with
+// http.Server.ListenAndServeTLS.
+type WebhookTLS struct {
+	Cert string
+	Key  string
+}
+
+// Poll implements Poller.
+func (w WebhookPoller) Poll(b *Bot, updates chan Update, stop chan struct{}) {
+	if err := setWebhook(b.Token, w.Endpoint, w.MaxConnections, w.DropPendingUpdates); err != nil {
+		log.Println("failed to set webhook:", err)
+		return
+	}
+
+	server := &http.Server{Addr: w.Listen, Handler: w.handler(updates)}
+
+	go func() {
+		<-stop
+		server.Close()
+	}()
+
+	var err error
+	if w.TLS != nil {
+		err = server.ListenAndServeTLS(w.TLS.Cert, w.TLS.Key)
+	} else {
+		err = server.ListenAndServe()
+	}
+	if err != nil && err != http.ErrServerClosed {
+		log.Println("webhook server stopped:", err)
+	}
+}
+
+// handler returns the http.Handler WebhookPoller uses to decode incoming
+// Updates from Telegram's webhook POST requests.
+func (w WebhookPoller) handler(updates chan Update) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		defer req.Body.Close()
+
+		var update Update
+		if err := json.NewDecoder(req.Body).Decode(&update); err != nil {
+			log.Println("failed to decode webhook update:", err)
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		updates <- update
+	})
+}
+
+// MiddlewarePoller decorates another Poller, letting updates be buffered
+// or filtered before they reach the Bot.
+type MiddlewarePoller struct {
+	Poller Poller
+
+	// Capacity sizes the internal buffering channel. Zero means
+	// unbuffered.
+	Capacity int
+
+	// Filter, when non-nil, is called for every update; updates for
+	// which it returns false are dropped.
+	Filter func(*Update) bool
+}
+
+// Poll implements Poller.
+func (m MiddlewarePoller) Poll(b *Bot, updates chan Update, stop chan struct{}) {
+	buffered := make(chan Update, m.Capacity)
+
+	go m.Poller.Poll(b, buffered, stop)
+
+	for {
+		select {
+		case <-stop:
+			return
+		case update := <-buffered:
+			if m.Filter != nil && !m.Filter(&update) {
+				continue
+			}
+			updates <- update
+		}
+	}
+}
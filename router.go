@@ -0,0 +1,258 @@
+package telegram
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// Endpoint sentinels for update kinds that aren't addressed by a command
+// or button, for use with Bot.Handle.
+const (
+	OnText            = "\aon_text"
+	OnPhoto           = "\aon_photo"
+	OnCallback        = "\aon_callback"
+	OnQuery           = "\aon_query"
+	OnEdited          = "\aon_edited"
+	OnChannelPost     = "\aon_channel_post"
+	OnMyChatMember    = "\aon_my_chat_member"
+	OnChatMember      = "\aon_chat_member"
+	OnChatJoinRequest = "\aon_chat_join_request"
+)
+
+// Context carries everything a HandlerFunc needs about the Update it was
+// dispatched for, plus a per-update key/value store for passing state down
+// the middleware chain.
+type Context interface {
+	// Message returns the Update's message, if any.
+	Message() *Message
+	// Sender returns the user who triggered the Update, if known.
+	Sender() *User
+	// Chat returns the chat the Update belongs to, if known.
+	Chat() *Chat
+	// Callback returns the Update's callback query, if any.
+	Callback() *Callback
+	// Query returns the Update's inline query, if any.
+	Query() *Query
+
+	// Send delivers what to the Context's chat.
+	Send(what Sendable, options *SendOptions) (*Message, error)
+	// Reply sends what as a reply to Message().
+	Reply(what Sendable, options *SendOptions) (*Message, error)
+	// Edit edits Message() (or the originating callback/inline result) to
+	// read as what.
+	Edit(what Sendable, options *SendOptions) (*Message, error)
+	// Respond answers the Update's callback query.
+	Respond(response *CallbackResponse) error
+
+	// Set stores value under key for the lifetime of this Update.
+	Set(key string, value interface{})
+	// Get retrieves a value previously stored with Set.
+	Get(key string) interface{}
+}
+
+// HandlerFunc processes a single routed Update.
+type HandlerFunc func(Context)
+
+// MiddlewareFunc wraps a HandlerFunc with additional behavior, e.g.
+// logging, recovery, auth or rate limiting.
+type MiddlewareFunc func(HandlerFunc) HandlerFunc
+
+// context is the default Context implementation, built by the router for
+// every Update it dispatches.
+type context struct {
+	bot    *Bot
+	update Update
+	store  map[string]interface{}
+}
+
+func (c *context) Message() *Message {
+	return c.update.Payload
+}
+
+func (c *context) Sender() *User {
+	switch {
+	case c.update.Payload != nil:
+		sender := c.update.Payload.Origin()
+		return &sender
+	case c.update.Callback != nil:
+		return &c.update.Callback.Sender
+	case c.update.Query != nil:
+		return &c.update.Query.Sender
+	}
+	return nil
+}
+
+func (c *context) Chat() *Chat {
+	switch {
+	case c.update.Payload != nil:
+		return &c.update.Payload.Chat
+	case c.update.Callback != nil && c.update.Callback.Message != nil:
+		return &c.update.Callback.Message.Chat
+	}
+	return nil
+}
+
+func (c *context) Callback() *Callback {
+	return c.update.Callback
+}
+
+func (c *context) Query() *Query {
+	return c.update.Query
+}
+
+func (c *context) Send(what Sendable, options *SendOptions) (*Message, error) {
+	return c.bot.Send(c.Chat(), what, options)
+}
+
+func (c *context) Reply(what Sendable, options *SendOptions) (*Message, error) {
+	if options == nil {
+		options = &SendOptions{}
+	}
+	if c.Message() != nil {
+		options.ReplyTo = *c.Message()
+	}
+	return c.bot.Send(c.Chat(), what, options)
+}
+
+func (c *context) Edit(what Sendable, options *SendOptions) (*Message, error) {
+	var editable Editable
+	switch {
+	case c.update.Payload != nil:
+		editable = c.update.Payload
+	case c.update.Callback != nil:
+		editable = c.update.Callback
+	default:
+		return nil, fmt.Errorf("telebot: nothing to edit in this update")
+	}
+
+	return c.bot.Edit(editable, what, options)
+}
+
+func (c *context) Respond(response *CallbackResponse) error {
+	if c.update.Callback == nil {
+		return nil
+	}
+	return c.bot.AnswerCallbackQuery(c.update.Callback, response)
+}
+
+func (c *context) Set(key string, value interface{}) {
+	if c.store == nil {
+		c.store = make(map[string]interface{})
+	}
+	c.store[key] = value
+}
+
+func (c *context) Get(key string) interface{} {
+	return c.store[key]
+}
+
+// Use appends middleware to the chain every routed handler runs under.
+// Middleware added first runs outermost.
+func (b *Bot) Use(mw ...MiddlewareFunc) {
+	b.middleware = append(b.middleware, mw...)
+}
+
+// Handle registers handler for endpoint, which may be a command string
+// such as "/start", a *ReplyButton (matched by its Text), an *InlineButton
+// (matched by its Unique callback data), or one of the On* sentinel
+// constants.
+func (b *Bot) Handle(endpoint interface{}, handler HandlerFunc) {
+	if b.handlers == nil {
+		b.handlers = make(map[string]HandlerFunc)
+	}
+
+	switch e := endpoint.(type) {
+	case string:
+		b.handlers[e] = handler
+	case *ReplyButton:
+		b.handlers[e.Text] = handler
+	case *InlineButton:
+		b.handlers[OnCallback+e.Unique] = handler
+	default:
+		log.Printf("telebot: unsupported endpoint type %T", endpoint)
+	}
+}
+
+// chain wraps handler with every middleware registered via Use, outermost
+// first.
+func (b *Bot) chain(handler HandlerFunc) HandlerFunc {
+	for i := len(b.middleware) - 1; i >= 0; i-- {
+		handler = b.middleware[i](handler)
+	}
+	return handler
+}
+
+// commandToken extracts the token Handle registered a command under from
+// text: "/start ref123" and "/start@mybot" (the suffix Telegram appends
+// in group chats) both route as "/start". Anything not starting with "/"
+// is returned unchanged, so literal non-command handlers still match in
+// full.
+func commandToken(text string) string {
+	if !strings.HasPrefix(text, "/") {
+		return text
+	}
+
+	token := text
+	if i := strings.IndexAny(token, " \n\t"); i >= 0 {
+		token = token[:i]
+	}
+	if i := strings.IndexByte(token, '@'); i >= 0 {
+		token = token[:i]
+	}
+	return token
+}
+
+// route resolves the HandlerFunc registered for update, or nil if nothing
+// matches.
+func (b *Bot) route(update Update) HandlerFunc {
+	switch {
+	case update.Callback != nil:
+		if h, ok := b.handlers[OnCallback+update.Callback.Data]; ok {
+			return h
+		}
+		return b.handlers[OnCallback]
+	case update.Query != nil:
+		return b.handlers[OnQuery]
+	case update.EditedMessage != nil:
+		return b.handlers[OnEdited]
+	case update.ChannelPost != nil:
+		return b.handlers[OnChannelPost]
+	case update.MyChatMember != nil:
+		return b.handlers[OnMyChatMember]
+	case update.ChatMember != nil:
+		return b.handlers[OnChatMember]
+	case update.ChatJoinRequest != nil:
+		return b.handlers[OnChatJoinRequest]
+	case update.Payload != nil:
+		if update.Payload.Photo != nil {
+			if h, ok := b.handlers[OnPhoto]; ok {
+				return h
+			}
+		}
+		if text := update.Payload.Text; text != "" {
+			if h, ok := b.handlers[commandToken(text)]; ok {
+				return h
+			}
+		}
+		return b.handlers[OnText]
+	}
+	return nil
+}
+
+// run dispatches update through the routed handler under the middleware
+// chain, recovering any panic the handler raises.
+func (b *Bot) run(update Update) {
+	handler := b.route(update)
+	if handler == nil {
+		return
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("telebot: handler panic recovered:", r)
+		}
+	}()
+
+	b.chain(handler)(&context{bot: b, update: update})
+}
@@ -0,0 +1,358 @@
+package telegram
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Sendable is anything Bot.Send knows how to deliver: Text, *Photo, *Video,
+// *Audio, *Document, *Sticker, *Voice, *VideoNote, *Location, *Venue and
+// *Contact all implement it.
+type Sendable interface {
+	Send(b *Bot, recipient Recipient, options *SendOptions) (*Message, error)
+}
+
+// Text is a Sendable wrapper around a plain text message, letting callers
+// pass a bare string to Bot.Send.
+type Text string
+
+// decodeMessage runs responseJSON through extractOk and, if it reports
+// success, decodes its Result into a Message. Every Sendable.Send
+// implementation ends with this.
+func decodeMessage(responseJSON []byte) (*Message, error) {
+	if err := extractOk(responseJSON); err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Result Message
+	}
+	if err := json.Unmarshal(responseJSON, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.Result, nil
+}
+
+// sendMedia uploads the file behind f, unless it's already known to
+// Telegram, in which case its FileID is reused and no upload takes place.
+// The upload itself streams from f.fileReader (FromReader) or, failing
+// that, from the local file at f.filename (FromDisk). The raw response
+// body is returned for the caller to decode. Both paths go through b.call
+// / b.callFile, so b.Limiter applies to both; a 429 is retried only on the
+// b.call (FileID) path, not on an upload.
+func sendMedia(b *Bot, recipient Recipient, method, field string, f *File, params map[string]string) ([]byte, error) {
+	if f.Exists() {
+		params[field] = f.FileID
+		return b.call(recipient, method, params)
+	}
+
+	reader := f.fileReader
+	if reader == nil {
+		opened, err := os.Open(f.filename)
+		if err != nil {
+			return nil, err
+		}
+		defer opened.Close()
+		reader = opened
+	}
+
+	return b.callFile(recipient, method, field, reader, params)
+}
+
+// Send posts the text message to recipient.
+func (t Text) Send(b *Bot, recipient Recipient, options *SendOptions) (*Message, error) {
+	params := map[string]string{
+		"chat_id": recipient.Destination(),
+		"text":    string(t),
+	}
+	if options != nil {
+		embedSendOptions(params, options)
+	}
+
+	responseJSON, err := b.call(recipient, "sendMessage", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeMessage(responseJSON)
+}
+
+// Send uploads and sends the photo to recipient.
+//
+// On success, p is aliased to its copy on the Telegram servers, so sending
+// the same Photo object again won't issue a new upload, but would make use
+// of the existing file on Telegram servers.
+func (p *Photo) Send(b *Bot, recipient Recipient, options *SendOptions) (*Message, error) {
+	params := map[string]string{
+		"chat_id": recipient.Destination(),
+		"caption": p.Caption,
+	}
+	if options != nil {
+		embedSendOptions(params, options)
+	}
+
+	responseJSON, err := sendMedia(b, recipient, "sendPhoto", "photo", &p.File, params)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := decodeMessage(responseJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	thumbnails := msg.Photo
+	filename := p.filename
+	p.File = thumbnails[len(thumbnails)-1].File
+	p.filename = filename
+
+	return msg, nil
+}
+
+// Send uploads and sends the video to recipient.
+//
+// On success, v is aliased to its copy on the Telegram servers, so sending
+// the same Video object again won't issue a new upload.
+func (v *Video) Send(b *Bot, recipient Recipient, options *SendOptions) (*Message, error) {
+	params := map[string]string{
+		"chat_id": recipient.Destination(),
+		"caption": v.Caption,
+	}
+	if options != nil {
+		embedSendOptions(params, options)
+	}
+
+	responseJSON, err := sendMedia(b, recipient, "sendVideo", "video", &v.File, params)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := decodeMessage(responseJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	filename := v.filename
+	v.File = msg.Video.File
+	v.filename = filename
+
+	return msg, nil
+}
+
+// Send uploads and sends the audio to recipient.
+//
+// On success, a is aliased to its copy on the Telegram servers, so sending
+// the same Audio object again won't issue a new upload.
+func (a *Audio) Send(b *Bot, recipient Recipient, options *SendOptions) (*Message, error) {
+	params := map[string]string{
+		"chat_id": recipient.Destination(),
+		"caption": a.Caption,
+	}
+	if options != nil {
+		embedSendOptions(params, options)
+	}
+
+	responseJSON, err := sendMedia(b, recipient, "sendAudio", "audio", &a.File, params)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := decodeMessage(responseJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	filename := a.filename
+	a.File = msg.Audio.File
+	a.filename = filename
+
+	return msg, nil
+}
+
+// Send uploads and sends the document to recipient.
+//
+// On success, d is aliased to its copy on the Telegram servers, so sending
+// the same Document object again won't issue a new upload.
+func (d *Document) Send(b *Bot, recipient Recipient, options *SendOptions) (*Message, error) {
+	params := map[string]string{
+		"chat_id": recipient.Destination(),
+		"caption": d.Caption,
+	}
+	if options != nil {
+		embedSendOptions(params, options)
+	}
+
+	responseJSON, err := sendMedia(b, recipient, "sendDocument", "document", &d.File, params)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := decodeMessage(responseJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	filename := d.filename
+	d.File = msg.Document.File
+	d.filename = filename
+
+	return msg, nil
+}
+
+// Send uploads and sends the sticker to recipient.
+//
+// On success, s is aliased to its copy on the Telegram servers, so sending
+// the same Sticker object again won't issue a new upload.
+func (s *Sticker) Send(b *Bot, recipient Recipient, options *SendOptions) (*Message, error) {
+	params := map[string]string{
+		"chat_id": recipient.Destination(),
+	}
+	if options != nil {
+		embedSendOptions(params, options)
+	}
+
+	responseJSON, err := sendMedia(b, recipient, "sendSticker", "sticker", &s.File, params)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := decodeMessage(responseJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	filename := s.filename
+	s.File = msg.Sticker.File
+	s.filename = filename
+
+	return msg, nil
+}
+
+// Send uploads and sends the voice note to recipient.
+//
+// On success, v is aliased to its copy on the Telegram servers, so sending
+// the same Voice object again won't issue a new upload.
+func (v *Voice) Send(b *Bot, recipient Recipient, options *SendOptions) (*Message, error) {
+	params := map[string]string{
+		"chat_id": recipient.Destination(),
+		"caption": v.Caption,
+	}
+	if options != nil {
+		embedSendOptions(params, options)
+	}
+
+	responseJSON, err := sendMedia(b, recipient, "sendVoice", "voice", &v.File, params)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := decodeMessage(responseJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	filename := v.filename
+	v.File = msg.Voice.File
+	v.filename = filename
+
+	return msg, nil
+}
+
+// Send uploads and sends the video note to recipient.
+//
+// On success, v is aliased to its copy on the Telegram servers, so sending
+// the same VideoNote object again won't issue a new upload.
+func (v *VideoNote) Send(b *Bot, recipient Recipient, options *SendOptions) (*Message, error) {
+	params := map[string]string{
+		"chat_id": recipient.Destination(),
+	}
+	if options != nil {
+		embedSendOptions(params, options)
+	}
+
+	responseJSON, err := sendMedia(b, recipient, "sendVideoNote", "video_note", &v.File, params)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := decodeMessage(responseJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	filename := v.filename
+	v.File = msg.VideoNote.File
+	v.filename = filename
+
+	return msg, nil
+}
+
+// Send posts the location to recipient.
+func (l *Location) Send(b *Bot, recipient Recipient, options *SendOptions) (*Message, error) {
+	params := map[string]string{
+		"chat_id":   recipient.Destination(),
+		"latitude":  fmt.Sprintf("%f", l.Latitude),
+		"longitude": fmt.Sprintf("%f", l.Longitude),
+	}
+	if options != nil {
+		embedSendOptions(params, options)
+	}
+
+	responseJSON, err := b.call(recipient, "sendLocation", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeMessage(responseJSON)
+}
+
+// Send posts the venue to recipient.
+func (v *Venue) Send(b *Bot, recipient Recipient, options *SendOptions) (*Message, error) {
+	params := map[string]string{
+		"chat_id":   recipient.Destination(),
+		"latitude":  fmt.Sprintf("%f", v.Location.Latitude),
+		"longitude": fmt.Sprintf("%f", v.Location.Longitude),
+		"title":     v.Title,
+		"address":   v.Address,
+	}
+	if v.FoursquareID != "" {
+		params["foursquare_id"] = v.FoursquareID
+	}
+	if v.FoursquareType != "" {
+		params["foursquare_type"] = v.FoursquareType
+	}
+	if options != nil {
+		embedSendOptions(params, options)
+	}
+
+	responseJSON, err := b.call(recipient, "sendVenue", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeMessage(responseJSON)
+}
+
+// Send posts the contact to recipient.
+func (c *Contact) Send(b *Bot, recipient Recipient, options *SendOptions) (*Message, error) {
+	params := map[string]string{
+		"chat_id":      recipient.Destination(),
+		"phone_number": c.PhoneNumber,
+		"first_name":   c.FirstName,
+	}
+	if c.LastName != "" {
+		params["last_name"] = c.LastName
+	}
+	if options != nil {
+		embedSendOptions(params, options)
+	}
+
+	responseJSON, err := b.call(recipient, "sendContact", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeMessage(responseJSON)
+}
@@ -1,6 +1,9 @@
 package telegram
 
-import "strconv"
+import (
+	"io"
+	"strconv"
+)
 
 // Recipient is basically any possible endpoint you can send
 // messages to. It's usually a distinct user or a chat.
@@ -64,6 +67,72 @@ type Update struct {
 	// optional
 	Callback *Callback `json:"callback_query"`
 	Query    *Query    `json:"inline_query"`
+
+	// EditedMessage is set instead of Payload when an existing message
+	// was edited.
+	EditedMessage *Message `json:"edited_message"`
+
+	// ChannelPost is set instead of Payload for posts in a channel the
+	// bot administers.
+	ChannelPost *Message `json:"channel_post"`
+
+	// MyChatMember fires whenever the bot's own status in a chat
+	// changes, e.g. it's added to or removed from a group.
+	MyChatMember *ChatMemberUpdate `json:"my_chat_member"`
+
+	// ChatMember fires whenever another member's status in a chat
+	// changes; requires subscribing via AllowedUpdates.
+	ChatMember *ChatMemberUpdate `json:"chat_member"`
+
+	// ChatJoinRequest fires when a user requests to join a chat that
+	// has join requests enabled.
+	ChatJoinRequest *ChatJoinRequest `json:"chat_join_request"`
+}
+
+// ChatMemberUpdate represents a change in a chat member's status,
+// delivered via Update.MyChatMember or Update.ChatMember.
+type ChatMemberUpdate struct {
+	Chat          Chat       `json:"chat"`
+	From          User       `json:"from"`
+	Date          int64      `json:"date"`
+	OldChatMember ChatMember `json:"old_chat_member"`
+	NewChatMember ChatMember `json:"new_chat_member"`
+}
+
+// ChatJoinRequest represents a pending request to join a chat, delivered
+// via Update.ChatJoinRequest.
+type ChatJoinRequest struct {
+	Chat       Chat            `json:"chat"`
+	From       User            `json:"from"`
+	Date       int64           `json:"date"`
+	Bio        string          `json:"bio,omitempty"`
+	InviteLink *ChatInviteLink `json:"invite_link,omitempty"`
+}
+
+// File represents any uploadable or downloadable Telegram file: the
+// contents of a Photo, Video, Audio, Document, Sticker, Voice or
+// VideoNote. Build one with FromDisk, FromURL, FromReader or FromFileID.
+type File struct {
+	FileID string `json:"file_id"`
+
+	// FilePath is populated by Telegram, either echoed back on send or
+	// fetched via Bot.FileByID; pass it to Bot.Download or Bot.File to
+	// retrieve the file's contents.
+	FilePath string `json:"file_path"`
+
+	// filename is the local path FromDisk uploads from.
+	filename string
+
+	// fileReader streams the upload body for FromReader, instead of
+	// reading it from disk.
+	fileReader io.Reader
+}
+
+// Exists reports whether f is already known to Telegram by FileID (or
+// references a URL Telegram can fetch itself), so sending it can reuse
+// the existing file instead of uploading anything.
+func (f File) Exists() bool {
+	return f.FileID != "" && f.fileReader == nil
 }
 
 // KeyboardButton represents a button displayed on in a message.
@@ -74,6 +143,20 @@ type KeyboardButton struct {
 	InlineQuery string `json:"switch_inline_query,omitempty"`
 }
 
+// ReplyButton is a CustomKeyboard button that Bot.Handle can route taps
+// on, matched by its Text.
+type ReplyButton struct {
+	Text string
+}
+
+// InlineButton is an InlineKeyboard button that Bot.Handle can route
+// taps on, matched by its Unique callback data.
+type InlineButton struct {
+	Unique string
+	Text   string
+	URL    string
+}
+
 // Photo object represents a photo with caption.
 type Photo struct {
 	File
@@ -94,3 +177,56 @@ type Location struct {
 	Latitude  float32 `json:"latitude"`
 	Longitude float32 `json:"longitude"`
 }
+
+// Video object represents a video file with caption.
+type Video struct {
+	File
+	Caption string
+}
+
+// Audio object represents an audio file with caption.
+type Audio struct {
+	File
+	Caption string
+}
+
+// Document object represents a general file with caption.
+type Document struct {
+	File
+	Caption string
+}
+
+// Sticker object represents a WebP image, standalone or within a set.
+type Sticker struct {
+	File
+}
+
+// Voice object represents a voice note with caption.
+type Voice struct {
+	File
+	Caption string
+}
+
+// VideoNote object represents a video message, rendered as a round video
+// player in clients.
+type VideoNote struct {
+	File
+}
+
+// Venue object represents a location bundled with additional information,
+// such as its title and address.
+type Venue struct {
+	Location
+
+	Title          string `json:"title"`
+	Address        string `json:"address"`
+	FoursquareID   string `json:"foursquare_id,omitempty"`
+	FoursquareType string `json:"foursquare_type,omitempty"`
+}
+
+// Contact object represents a contact shared with the bot.
+type Contact struct {
+	PhoneNumber string `json:"phone_number"`
+	FirstName   string `json:"first_name"`
+	LastName    string `json:"last_name,omitempty"`
+}